@@ -0,0 +1,135 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// lruCache is a fixed-size, in-process cache mapping token hash to token
+// value. It sits in front of a TokenStore so repeated lookups of hot
+// hashes skip the database entirely.
+type lruCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // front = most recently used
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type lruEntry struct {
+	hash  string
+	value int
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *lruCache) get(hash string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[hash]
+	if !ok {
+		c.misses.Add(1)
+		c.recordHitRatio()
+		return 0, false
+	}
+	c.hits.Add(1)
+	c.recordHitRatio()
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+// recordHitRatio updates the cacheHitRatio gauge. It's called with mu
+// already held, which is fine since it only reads the atomic counters.
+func (c *lruCache) recordHitRatio() {
+	hits, misses := c.hits.Load(), c.misses.Load()
+	if total := hits + misses; total > 0 {
+		cacheHitRatio.Set(float64(hits) / float64(total))
+	}
+}
+
+func (c *lruCache) set(hash string, value int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[hash]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&lruEntry{hash: hash, value: value})
+	c.items[hash] = el
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).hash)
+		}
+	}
+}
+
+// cachedStore wraps a TokenStore with an lruCache, serving hits from
+// memory and only falling through to the underlying store on misses.
+type cachedStore struct {
+	TokenStore
+	cache *lruCache
+}
+
+// withCache wraps store with an LRU cache of the given capacity. A
+// capacity of 0 disables caching and returns store unchanged.
+func withCache(store TokenStore, capacity int) TokenStore {
+	if capacity <= 0 {
+		return store
+	}
+	return &cachedStore{TokenStore: store, cache: newLRUCache(capacity)}
+}
+
+func (s *cachedStore) Lookup(ctx context.Context, hash string) (int, error) {
+	if value, ok := s.cache.get(hash); ok {
+		return value, nil
+	}
+	value, err := s.TokenStore.Lookup(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	s.cache.set(hash, value)
+	return value, nil
+}
+
+func (s *cachedStore) BatchLookup(ctx context.Context, hashes []string) (map[string]int, error) {
+	results := make(map[string]int, len(hashes))
+	var misses []string
+
+	for _, hash := range hashes {
+		if value, ok := s.cache.get(hash); ok {
+			results[hash] = value
+		} else {
+			misses = append(misses, hash)
+		}
+	}
+	if len(misses) == 0 {
+		return results, nil
+	}
+
+	fetched, err := s.TokenStore.BatchLookup(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for hash, value := range fetched {
+		s.cache.set(hash, value)
+		results[hash] = value
+	}
+	return results, nil
+}
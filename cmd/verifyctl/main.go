@@ -0,0 +1,47 @@
+// Command verifyctl streams token hashes from stdin (one per line) to a
+// TokenVerifier gRPC service and prints each result as it arrives.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gklps/TokenHashGenerator/pkg/verifierclient"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "TokenVerifier gRPC address")
+	flag.Parse()
+
+	client, err := verifierclient.Dial(*addr)
+	if err != nil {
+		log.Fatalf("dialing %s: %v", *addr, err)
+	}
+	defer client.Close()
+
+	hashes := make(chan string)
+	go func() {
+		defer close(hashes)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				hashes <- line
+			}
+		}
+	}()
+
+	err = client.VerifyStream(context.Background(), hashes, func(r verifierclient.Result) {
+		if r.Valid {
+			fmt.Printf("%s\tvalid\n", r.TokenHash)
+		} else {
+			fmt.Printf("%s\tinvalid\t%s\n", r.TokenHash, r.Reason)
+		}
+	})
+	if err != nil {
+		log.Fatalf("verifying: %v", err)
+	}
+}
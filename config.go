@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StoreConfig describes how to connect to the backing TokenStore.
+//
+// It can be populated from a config.yaml file or overridden with
+// environment variables (TOKENSTORE_DRIVER, TOKENSTORE_DSN,
+// TOKENSTORE_MAX_OPEN_CONNS), which take precedence over the file so the
+// same config.yaml can be reused across environments.
+type StoreConfig struct {
+	Driver       string `yaml:"driver"`
+	DSN          string `yaml:"dsn"`
+	MaxOpenConns int    `yaml:"max_open_conns"`
+
+	// CacheSize is the number of hash->value entries kept in the
+	// in-process LRU cache in front of the store. 0 disables caching.
+	CacheSize int `yaml:"cache_size"`
+}
+
+// defaultStoreConfig is used when no config.yaml is present and no
+// environment variables are set, preserving the historical behavior of
+// the verifier (a local SQLite file).
+func defaultStoreConfig() StoreConfig {
+	return StoreConfig{
+		Driver:       "sqlite3",
+		DSN:          "./token_data.db",
+		MaxOpenConns: 10,
+		CacheSize:    10000,
+	}
+}
+
+// LoadStoreConfig reads the store configuration from path (if it exists)
+// and then applies any TOKENSTORE_* environment variable overrides.
+func LoadStoreConfig(path string) (StoreConfig, error) {
+	cfg := defaultStoreConfig()
+
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return cfg, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if v := os.Getenv("TOKENSTORE_DRIVER"); v != "" {
+		cfg.Driver = v
+	}
+	if v := os.Getenv("TOKENSTORE_DSN"); v != "" {
+		cfg.DSN = v
+	}
+	if v := os.Getenv("TOKENSTORE_MAX_OPEN_CONNS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parsing TOKENSTORE_MAX_OPEN_CONNS: %w", err)
+		}
+		cfg.MaxOpenConns = n
+	}
+	if v := os.Getenv("TOKENSTORE_CACHE_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return cfg, fmt.Errorf("parsing TOKENSTORE_CACHE_SIZE: %w", err)
+		}
+		cfg.CacheSize = n
+	}
+
+	if cfg.Driver == "" {
+		return cfg, fmt.Errorf("store config: driver is required")
+	}
+	if cfg.DSN == "" {
+		return cfg, fmt.Errorf("store config: dsn is required")
+	}
+
+	return cfg, nil
+}
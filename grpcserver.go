@@ -0,0 +1,82 @@
+package main
+
+//go:generate buf generate
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/gklps/TokenHashGenerator/proto/verifierpb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// startGRPCServer listens on addr and serves the TokenVerifier gRPC
+// service until the listener errors or the process exits. It's started
+// alongside the Echo HTTP server in main, on its own port, since gRPC
+// and net/http don't share a listener without extra multiplexing that
+// this service doesn't need.
+func startGRPCServer(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s := grpc.NewServer()
+	verifierpb.RegisterTokenVerifierServer(s, &tokenVerifierServer{})
+	return s.Serve(lis)
+}
+
+// tokenVerifierServer implements the verifierpb.TokenVerifierServer
+// interface generated from proto/verifier.proto (see Makefile's `proto`
+// target). It reuses the same parseToken/verifyOne logic as the HTTP and
+// NDJSON handlers so all three transports agree on what "valid" means.
+type tokenVerifierServer struct {
+	verifierpb.UnimplementedTokenVerifierServer
+}
+
+func (s *tokenVerifierServer) Verify(stream verifierpb.TokenVerifier_VerifyServer) error {
+	inFlightRequests.Inc()
+	defer inFlightRequests.Dec()
+	defer func(start time.Time) {
+		handlerDuration.WithLabelValues("verify_grpc").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		p := parseToken(req.TokenHash)
+		var values map[string]int
+		if p.valid {
+			// TokenStore is deliberately hash-only (see sqlStore in
+			// store.go), so the level being looked up is attached here
+			// rather than inside BatchLookup itself.
+			ctx, span := tracer.Start(stream.Context(), "tokenVerifierServer.Verify.BatchLookup", trace.WithAttributes(
+				attribute.Int("token.level", p.level),
+			))
+			values, err = store.BatchLookup(ctx, []string{p.hash})
+			span.End()
+			if err != nil {
+				return err
+			}
+		}
+		valid, reason := verifyOne(p, values)
+		tokenVerifyTotal.WithLabelValues(resultLabel(valid, reason)).Inc()
+
+		if err := stream.Send(&verifierpb.VerifyResponse{
+			TokenHash: req.TokenHash,
+			Valid:     valid,
+			Reason:    reason,
+		}); err != nil {
+			return err
+		}
+	}
+}
@@ -1,15 +1,19 @@
 package main
 
 import (
-	"database/sql"
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
-	_ "github.com/mattn/go-sqlite3" // SQLite driver
+	"github.com/gklps/TokenHashGenerator/pkg/tokenmap"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TokenVerificationResponse represents the structure of the JSON response.
@@ -17,183 +21,210 @@ type TokenVerificationResponse struct {
 	Results map[string]bool `json:"results"`
 }
 
-// TokenMap is your provided mapping of levels to maximum token values.
-var TokenMap = map[int]int{
-	0:  0,
-	1:  5000000,
-	2:  2425000,
-	3:  2303750,
-	4:  2188563,
-	5:  2079134,
-	6:  1975178,
-	7:  1876419,
-	8:  1782598,
-	9:  1693468,
-	10: 1608795,
-	11: 1528355,
-	12: 1451937,
-	13: 1379340,
-	14: 1310373,
-	15: 1244855,
-	16: 1182612,
-	17: 1123481,
-	18: 1067307,
-	19: 1013942,
-	20: 963245,
-	21: 915082,
-	22: 869328,
-	23: 825862,
-	24: 784569,
-	25: 745340,
-	26: 708073,
-	27: 672670,
-	28: 639036,
-	29: 607084,
-	30: 576730,
-	31: 547894,
-	32: 520499,
-	33: 494474,
-	34: 469750,
-	35: 446263,
-	36: 423950,
-	37: 402752,
-	38: 382615,
-	39: 363484,
-	40: 345310,
-	41: 328044,
-	42: 311642,
-	43: 296060,
-	44: 281257,
-	45: 267194,
-	46: 253834,
-	47: 241143,
-	48: 229085,
-	49: 217631,
-	50: 206750,
-	51: 196412,
-	52: 186592,
-	53: 177262,
-	54: 168399,
-	55: 159979,
-	56: 151980,
-	57: 144381,
-	58: 137162,
-	59: 130304,
-	60: 117273,
-	61: 105546,
-	62: 94992,
-	63: 85492,
-	64: 76943,
-	65: 69249,
-	66: 62324,
-	67: 56092,
-	68: 50482,
-	69: 45434,
-	70: 40891,
-	71: 36802,
-	72: 33121,
-	73: 29809,
-	74: 26828,
-	75: 24146,
-	76: 21731,
-	77: 19558,
-	78: 17602,
-}
+// store is the TokenStore backing every verification request. It is
+// opened once in main and shared across handlers, relying on its
+// underlying connection pool instead of a global mutex.
+var store TokenStore
+
+// levels is the level -> max-token-value mapping, loaded from disk (see
+// pkg/tokenmap) instead of hardcoded so operators can roll out a new
+// schedule without a rebuild.
+var levels *tokenmap.Store
+
+// tokenmapPathEnv overrides the default tokenmap.yaml path; --tokenmap
+// takes precedence over it.
+const tokenmapPathEnv = "TOKENMAP_PATH"
 
-var db *sql.DB
-var mutex sync.Mutex
+func defaultTokenmapPath() string {
+	if p := os.Getenv(tokenmapPathEnv); p != "" {
+		return p
+	}
+	return "tokenmap.yaml"
+}
 
 func main() {
-	// Open database connection (once)
-	var err error
-	db, err = sql.Open("sqlite3", "./token_data.db")
+	tokenmapPath := flag.String("tokenmap", defaultTokenmapPath(), "path to the tokenmap YAML/JSON file")
+	flag.Parse()
+
+	ctx := context.Background()
+	shutdownTracing, err := setupTracing(ctx)
+	if err != nil {
+		fmt.Printf("Error setting up tracing: %v\n", err)
+		return
+	}
+	defer shutdownTracing(ctx)
+
+	levels, err = tokenmap.NewStore(*tokenmapPath)
+	if err != nil {
+		fmt.Printf("Error loading tokenmap: %v\n", err)
+		return
+	}
+	watchCtx, cancelWatch := context.WithCancel(ctx)
+	defer cancelWatch()
+	go func() {
+		if err := levels.Watch(watchCtx); err != nil && watchCtx.Err() == nil {
+			fmt.Printf("tokenmap watcher stopped: %v\n", err)
+		}
+	}()
+
+	cfg, err := LoadStoreConfig("config.yaml")
 	if err != nil {
-		fmt.Printf("Error opening database: %v\n", err)
+		fmt.Printf("Error loading store config: %v\n", err)
 		return
 	}
-	defer db.Close()
 
-	// Prepare the database statements (once)
-	prepareStatements(db) // Function to prepare statements (see below)
+	store, err = NewTokenStore(cfg)
+	if err != nil {
+		fmt.Printf("Error opening token store: %v\n", err)
+		return
+	}
+	defer store.Close()
 
-	// HTTP handler
-	http.HandleFunc("/verify", verifyTokensHandler)
+	e, err := newServer()
+	if err != nil {
+		fmt.Printf("Error building server: %v\n", err)
+		return
+	}
+
+	go func() {
+		if err := startGRPCServer(":9090"); err != nil {
+			fmt.Printf("gRPC server error: %v\n", err)
+		}
+	}()
 
-	// Start server
 	fmt.Println("Server listening on port 8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
+	if err := e.Start(":8080"); err != nil {
 		fmt.Printf("Server error: %v\n", err)
 	}
 }
 
-// Prepared statements (for better performance)
-var stmtVerifyToken *sql.Stmt
+// parsedToken is a tokenHash split into its level prefix and the raw hash
+// that is actually looked up in the store.
+type parsedToken struct {
+	tokenHash string
+	level     int
+	hash      string
+	valid     bool // false if tokenHash failed the length check
+}
 
-func prepareStatements(db *sql.DB) {
-	var err error
-	stmtVerifyToken, err = db.Prepare("SELECT token_value FROM tokens WHERE token_hash = ?")
-	if err != nil {
-		fmt.Printf("Error preparing statement: %v\n", err)
-		return // Or handle the error more gracefully
+func parseToken(tokenHash string) parsedToken {
+	if len(tokenHash) != 67 {
+		fmt.Printf("Invalid token length: %s\n", tokenHash)
+		return parsedToken{tokenHash: tokenHash}
+	}
+
+	levelStr := strings.TrimLeft(tokenHash[:3], "0")
+	level, _ := strconv.Atoi(levelStr)
+
+	return parsedToken{
+		tokenHash: tokenHash,
+		level:     level,
+		hash:      tokenHash[3:],
+		valid:     true,
 	}
 }
 
-func verifyTokensHandler(w http.ResponseWriter, r *http.Request) {
+// verifyOne resolves a single parsed token against a set of already
+// looked-up store values, returning whether it's valid and, if not, a
+// short machine-readable reason. It's shared by the batch JSON handler,
+// the NDJSON stream handler, and the gRPC service so the three transports
+// agree on what "valid" means.
+func verifyOne(p parsedToken, values map[string]int) (valid bool, reason string) {
+	if !p.valid {
+		return false, "invalid_length"
+	}
+	max, ok := levels.Get(p.level)
+	if !ok {
+		return false, "level_out_of_range"
+	}
+	tokenNumberFromFile, found := values[p.hash]
+	if !found {
+		return false, "not_found"
+	}
+	if !(0 < tokenNumberFromFile && tokenNumberFromFile <= max) {
+		return false, "out_of_range"
+	}
+	return true, ""
+}
+
+// distinctLevels returns the distinct levels among parsed's valid tokens,
+// for attaching to the BatchLookup span as an attribute since a single
+// batch can mix tokens from several levels.
+func distinctLevels(parsed []parsedToken) []int {
+	seen := make(map[int]struct{}, len(parsed))
+	result := make([]int, 0, len(parsed))
+	for _, p := range parsed {
+		if !p.valid {
+			continue
+		}
+		if _, ok := seen[p.level]; ok {
+			continue
+		}
+		seen[p.level] = struct{}{}
+		result = append(result, p.level)
+	}
+	return result
+}
+
+// verifyTokensHandler verifies a batch of tokenHashes in a single round
+// trip to the store: it deduplicates the raw hashes and issues one
+// BatchLookup instead of spawning a goroutine (and a DB call) per token.
+func verifyTokensHandler(c echo.Context) error {
+	inFlightRequests.Inc()
+	defer inFlightRequests.Dec()
+	defer func(start time.Time) {
+		handlerDuration.WithLabelValues("verify").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	var input struct {
 		Tokens []string `json:"tokens"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	if err := c.Bind(&input); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	results := make(map[string]bool, len(input.Tokens))
-	// Use WaitGroup for concurrent processing
-	var wg sync.WaitGroup
-	wg.Add(len(input.Tokens)) // Set the number of goroutines to wait for
-	for _, tokenHash := range input.Tokens {
-		go func(tokenHash string) {
-			defer wg.Done() // Signal when the goroutine is done
-
-			levelStr := strings.TrimLeft(tokenHash[:3], "0")
-			level, _ := strconv.Atoi(levelStr)
-
-			hash := tokenHash[3:]
-			fmt.Printf("Verifying token: %s (level %d)\n", hash, level)
-			// Length check:
-			if len(tokenHash) != 67 {
-				fmt.Printf("Invalid token length: %s\n", tokenHash)
-				results[tokenHash] = false
-				return // Skip further processing if the length is invalid
+	parsed := make([]parsedToken, len(input.Tokens))
+	hashes := make([]string, 0, len(input.Tokens))
+	for i, tokenHash := range input.Tokens {
+		parsed[i] = parseToken(tokenHash)
+		if parsed[i].valid {
+			hashes = append(hashes, parsed[i].hash)
+			if _, ok := levels.Get(parsed[i].level); !ok {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity,
+					fmt.Sprintf("token %s: level %d is outside the loaded tokenmap (version %s)",
+						parsed[i].tokenHash, parsed[i].level, levels.Version()))
 			}
+		}
+	}
 
-			// Lock the mutex to ensure safe concurrent access to the database
-			mutex.Lock()
-			var tokenNumberFromFile int
-			err := stmtVerifyToken.QueryRow(hash).Scan(&tokenNumberFromFile) // Use the prepared statement
-			if err != nil {
-				if err == sql.ErrNoRows {
-					fmt.Printf("Hash not found in database: %s\n", hash)
-				} else {
-					// Log or handle the database error
-					fmt.Printf("Database error: %v\n", err)
-				}
-				results[tokenHash] = false
-			} else {
-				fmt.Printf("Token number: %d (from database), Max value for level %d: %d\n", tokenNumberFromFile, level, TokenMap[level])
-				results[tokenHash] = 0 < tokenNumberFromFile && tokenNumberFromFile <= TokenMap[level]
-			}
-			// Unlock the mutex
-			mutex.Unlock()
-		}(tokenHash) // Passing the tokenHash argument to the goroutine
+	// TokenStore is deliberately hash-only (see sqlStore in store.go), so
+	// the level(s) being looked up are attached here rather than inside
+	// BatchLookup itself.
+	ctx, span := tracer.Start(c.Request().Context(), "verifyTokensHandler.BatchLookup", trace.WithAttributes(
+		attribute.IntSlice("token.levels", distinctLevels(parsed)),
+	))
+	values, err := store.BatchLookup(ctx, hashes)
+	span.End()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	// Wait for all goroutines to finish
-	wg.Wait()
+	results := make(map[string]bool, len(parsed))
+	for _, p := range parsed {
+		valid, reason := verifyOne(p, values)
+		if !valid {
+			fmt.Printf("Token %s invalid: %s\n", p.tokenHash, reason)
+		}
+		tokenVerifyTotal.WithLabelValues(resultLabel(valid, reason)).Inc()
+		results[p.tokenHash] = valid
+	}
+
+	if sub, ok := c.Get("jwt_subject").(string); ok && sub != "" {
+		fmt.Printf("Verification request audited for subject: %s\n", sub)
+	}
 
 	// Create a TokenVerificationResponse struct and populate it with the results.
 	response := TokenVerificationResponse{Results: results}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response) // Encode the map
+	c.Response().Header().Set("X-TokenMap-Version", levels.Version())
+	return c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metric names follow the token_verify_* / token_verify_handler_*
+// convention so they group together in Prometheus/Grafana without a
+// namespace prefix, matching the single-purpose nature of this service.
+var (
+	tokenVerifyTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "token_verify_total",
+		Help: "Count of individual token verifications by result.",
+	}, []string{"result"})
+
+	dbLookupDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "token_verify_db_lookup_duration_seconds",
+		Help:    "Latency of TokenStore lookups.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "token_verify_handler_duration_seconds",
+		Help:    "End-to-end latency of the verification handlers.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"handler"})
+
+	inFlightRequests = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "token_verify_in_flight_requests",
+		Help: "Number of verification requests currently being handled.",
+	})
+
+	cacheHitRatio = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "token_verify_cache_hit_ratio",
+		Help: "Fraction of lookups served from the in-process LRU cache since the process started.",
+	})
+)
+
+// resultLabel maps a verifyOne outcome to the result label used by
+// tokenVerifyTotal.
+func resultLabel(valid bool, reason string) string {
+	if valid {
+		return "valid"
+	}
+	if reason == "" {
+		return "error"
+	}
+	return reason
+}
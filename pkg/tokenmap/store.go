@@ -0,0 +1,117 @@
+package tokenmap
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Store holds the currently active Map and knows how to reload it from
+// disk, so callers always read through Store rather than holding a *Map
+// directly. Get is safe for concurrent use while a reload is in flight.
+type Store struct {
+	path    string
+	current atomic.Pointer[Map]
+}
+
+// NewStore loads path once and returns a Store serving that mapping.
+// Call Watch to pick up subsequent changes to the file.
+func NewStore(path string) (*Store, error) {
+	m, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{path: path}
+	s.current.Store(m)
+	return s, nil
+}
+
+// Get returns the max token value for level from whichever Map is
+// currently active.
+func (s *Store) Get(level int) (max int, ok bool) {
+	return s.current.Load().Get(level)
+}
+
+// Version returns the version of the currently active Map.
+func (s *Store) Version() string {
+	return s.current.Load().Version
+}
+
+// Reload re-reads and re-validates the file at s.path and, if it's
+// valid, swaps it in atomically. An invalid file is logged and ignored,
+// leaving the previously loaded mapping in place rather than taking the
+// verifier down.
+func (s *Store) Reload() error {
+	m, err := Load(s.path)
+	if err != nil {
+		return err
+	}
+	s.current.Store(m)
+	return nil
+}
+
+// Watch blocks, reloading the tokenmap whenever s.path changes on disk or
+// the process receives SIGHUP, until ctx is canceled. Reload failures are
+// logged, not fatal, since the file may be mid-write by the tool that
+// produced it.
+func (s *Store) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("starting tokenmap watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the parent directory rather than s.path itself: a write-temp-
+	// then-rename update (the standard way deployment tooling and
+	// ConfigMap mounts replace a live file) swaps in a new inode, which
+	// detaches a watch held on the old one and would otherwise silently
+	// stop all future reloads.
+	dir := filepath.Dir(s.path)
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("watching %s: %w", dir, err)
+	}
+	target := filepath.Clean(s.path)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	reload := func(trigger string) {
+		if err := s.Reload(); err != nil {
+			log.Printf("tokenmap: reload from %s failed: %v", trigger, err)
+			return
+		}
+		log.Printf("tokenmap: reloaded from %s (version %s)", trigger, s.Version())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				reload("file change")
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("tokenmap: watcher error: %v", err)
+		case <-hup:
+			reload("SIGHUP")
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package tokenmap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestStore_WatchSurvivesAtomicReplace verifies Reload still fires after
+// the tokenmap file is replaced via write-temp-then-rename, the way
+// deployment tooling and ConfigMap mounts update a live file -- as
+// opposed to an in-place write to the existing inode.
+func TestStore_WatchSurvivesAtomicReplace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tokenmap.yaml")
+
+	if err := os.WriteFile(path, []byte("version: \"v1\"\nlevels:\n  0: 0\n  1: 100\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- s.Watch(ctx) }()
+	time.Sleep(100 * time.Millisecond) // let the watcher register before we rename
+
+	tmp := filepath.Join(dir, "tokenmap.yaml.tmp")
+	if err := os.WriteFile(tmp, []byte("version: \"v2\"\nlevels:\n  0: 0\n  1: 200\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for s.Version() != "v2" {
+		select {
+		case <-deadline:
+			t.Fatalf("tokenmap was never reloaded after atomic replace, version stuck at %q", s.Version())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+}
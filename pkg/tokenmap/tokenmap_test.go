@@ -0,0 +1,45 @@
+package tokenmap
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		limits  map[int]int
+		wantErr bool
+	}{
+		{
+			name:   "valid schedule",
+			limits: map[int]int{0: 0, 1: 5000000, 2: 2425000, 3: 2303750},
+		},
+		{
+			name:    "empty",
+			limits:  map[int]int{},
+			wantErr: true,
+		},
+		{
+			name:    "gap in levels",
+			limits:  map[int]int{0: 0, 1: 100, 3: 50},
+			wantErr: true,
+		},
+		{
+			name:    "increasing after peak",
+			limits:  map[int]int{0: 0, 1: 100, 2: 150},
+			wantErr: true,
+		},
+		{
+			name:    "negative level",
+			limits:  map[int]int{-1: 0, 0: 0},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validate(tc.limits)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("validate(%v) error = %v, wantErr %v", tc.limits, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package verifierclient is a thin wrapper around the TokenVerifier gRPC
+// service for callers that want to stream hashes in and results out
+// without reimplementing the bidi-streaming boilerplate.
+package verifierclient
+
+import (
+	"context"
+	"io"
+
+	"github.com/gklps/TokenHashGenerator/proto/verifierpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client is a connected handle to a TokenVerifier service.
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  verifierpb.TokenVerifierClient
+}
+
+// Dial connects to the TokenVerifier service at addr. The caller must
+// call Close when done with the returned Client.
+func Dial(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: verifierpb.NewTokenVerifierClient(conn)}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Result is a single verification outcome, mirroring verifierpb.VerifyResponse.
+type Result struct {
+	TokenHash string
+	Valid     bool
+	Reason    string
+}
+
+// VerifyStream sends every hash from hashes and invokes onResult for each
+// response as it arrives, in the order the server returns them (which
+// matches arrival order). It blocks until every hash has a result or ctx
+// is canceled.
+func (c *Client) VerifyStream(ctx context.Context, hashes <-chan string, onResult func(Result)) error {
+	stream, err := c.rpc.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		for hash := range hashes {
+			if err := stream.Send(&verifierpb.VerifyRequest{TokenHash: hash}); err != nil {
+				errc <- err
+				return
+			}
+		}
+		errc <- stream.CloseSend()
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return <-errc
+		}
+		if err != nil {
+			return err
+		}
+		onResult(Result{TokenHash: resp.TokenHash, Valid: resp.Valid, Reason: resp.Reason})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// jwtSigningKeyEnv is the environment variable holding the HMAC key used
+// to validate the JWTs presented to /verify. There is no compiled-in
+// default: the server refuses to start without it.
+const jwtSigningKeyEnv = "JWT_SIGNING_KEY"
+
+// newServer builds the Echo instance used by main, wiring up the
+// request-logging, recovery, gzip, CORS, and request-ID middleware along
+// with JWT protection for /verify and the /healthz, /readyz, and
+// /metrics routes.
+func newServer() (*echo.Echo, error) {
+	signingKey := os.Getenv(jwtSigningKeyEnv)
+	if signingKey == "" {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, jwtSigningKeyEnv+" is required")
+	}
+
+	e := echo.New()
+	e.HideBanner = true
+
+	e.Use(echomw.RequestID())
+	e.Use(echomw.Recover())
+	e.Use(echomw.Gzip())
+	e.Use(echomw.CORS())
+	e.Use(otelEchoMiddleware("token-verifier"))
+	e.Use(echomw.LoggerWithConfig(echomw.LoggerConfig{
+		Format: `{"time":"${time_rfc3339}","id":"${id}","method":"${method}","uri":"${uri}",` +
+			`"status":${status},"latency_ms":${latency_human}}` + "\n",
+	}))
+
+	e.GET("/healthz", healthzHandler)
+	e.GET("/readyz", readyzHandler)
+	e.GET("/metrics", metricsHandler)
+
+	verify := e.Group("/verify")
+	verify.Use(echojwt.WithConfig(echojwt.Config{
+		SigningKey: []byte(signingKey),
+		SuccessHandler: func(c echo.Context) {
+			if token, ok := c.Get("user").(*jwt.Token); ok {
+				if claims, ok := token.Claims.(jwt.MapClaims); ok {
+					if sub, ok := claims["sub"].(string); ok {
+						c.Set("jwt_subject", sub)
+					}
+				}
+			}
+		},
+	}))
+	verify.POST("", verifyTokensHandler)
+	verify.POST("/stream", verifyTokensStreamHandler)
+
+	return e, nil
+}
+
+func healthzHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{
+		"status":           "ok",
+		"tokenmap_version": levels.Version(),
+	})
+}
+
+func readyzHandler(c echo.Context) error {
+	if store == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "store not initialized")
+	}
+	if err := store.Ping(); err != nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func metricsHandler(c echo.Context) error {
+	promhttp.Handler().ServeHTTP(c.Response(), c.Request())
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gklps/TokenHashGenerator/pkg/tokenmap"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// memStore is a minimal in-memory TokenStore for handler/middleware tests
+// that don't care about the actual lookup path.
+type memStore struct {
+	values map[string]int
+}
+
+func (s *memStore) Lookup(_ context.Context, hash string) (int, error) {
+	v, ok := s.values[hash]
+	if !ok {
+		return 0, ErrTokenNotFound
+	}
+	return v, nil
+}
+
+func (s *memStore) BatchLookup(_ context.Context, hashes []string) (map[string]int, error) {
+	results := make(map[string]int, len(hashes))
+	for _, h := range hashes {
+		if v, ok := s.values[h]; ok {
+			results[h] = v
+		}
+	}
+	return results, nil
+}
+
+func (s *memStore) Close() error { return nil }
+func (s *memStore) Ping() error  { return nil }
+
+func newTestLevels(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tokenmap.yaml")
+	if err := os.WriteFile(path, []byte("version: \"test\"\nlevels:\n  0: 0\n  1: 100\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := tokenmap.NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	levels = store
+}
+
+func TestNewServer_RequiresSigningKey(t *testing.T) {
+	os.Unsetenv(jwtSigningKeyEnv)
+	if _, err := newServer(); err == nil {
+		t.Fatal("expected an error when JWT_SIGNING_KEY is unset")
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	newTestLevels(t)
+	t.Setenv(jwtSigningKeyEnv, "test-secret")
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	newTestLevels(t)
+	t.Setenv(jwtSigningKeyEnv, "test-secret")
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("store nil", func(t *testing.T) {
+		store = nil
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("store ready", func(t *testing.T) {
+		store = &memStore{}
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestVerifyRequiresJWT(t *testing.T) {
+	newTestLevels(t)
+	t.Setenv(jwtSigningKeyEnv, "test-secret")
+	store = &memStore{}
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/verify", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// echojwt reports a missing token as 400 Bad Request; it only returns
+	// 401 once a token was found but failed to parse/validate.
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// mustSignedJWT returns a JWT signed with signingKey, suitable for driving
+// requests through the /verify group's echojwt middleware in tests.
+func mustSignedJWT(t *testing.T, signingKey string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "test-client",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(signingKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerifyWithValidJWT(t *testing.T) {
+	newTestLevels(t)
+	signingKey := "test-secret"
+	t.Setenv(jwtSigningKeyEnv, signingKey)
+	store = &memStore{}
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := mustSignedJWT(t, signingKey)
+
+	body := strings.NewReader(`{"tokens":[]}`)
+	req := httptest.NewRequest(http.MethodPost, "/verify", body)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body=%s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql" // MySQL driver
+	_ "github.com/lib/pq"              // Postgres driver
+	_ "github.com/mattn/go-sqlite3"    // SQLite driver
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer emits the spans wrapping every store lookup, named after this
+// package's import path per OTel convention.
+var tracer = otel.Tracer("github.com/gklps/TokenHashGenerator")
+
+// hashPrefixLen is how much of a hash is attached to spans as an
+// attribute — enough to correlate with logs, short enough to not leak
+// the full (sensitive) hash into tracing backends.
+const hashPrefixLen = 8
+
+func hashPrefix(hash string) string {
+	if len(hash) <= hashPrefixLen {
+		return hash
+	}
+	return hash[:hashPrefixLen]
+}
+
+// ErrTokenNotFound is returned by Lookup and included in the BatchLookup
+// results when a hash has no matching row in the store.
+var ErrTokenNotFound = errors.New("token hash not found")
+
+// TokenStore abstracts the backing database for token verification so the
+// HTTP layer never talks to database/sql directly. This lets the verifier
+// run against SQLite, Postgres, or MySQL interchangeably, selected at
+// startup via StoreConfig.
+type TokenStore interface {
+	// Lookup returns the stored token value for hash, or ErrTokenNotFound
+	// if no row matches. ctx carries the caller's trace so the lookup
+	// shows up as a child span of the handler that requested it.
+	Lookup(ctx context.Context, hash string) (value int, err error)
+
+	// BatchLookup returns the stored token values for every hash in
+	// hashes that was found. Hashes with no matching row are simply
+	// omitted from the result map; callers should treat their absence as
+	// ErrTokenNotFound.
+	BatchLookup(ctx context.Context, hashes []string) (map[string]int, error)
+
+	// Close releases the underlying database connection(s).
+	Close() error
+
+	// Ping reports whether the store's backing database is reachable,
+	// used by the /readyz endpoint.
+	Ping() error
+}
+
+// sqlStore is a TokenStore backed by database/sql, shared by the SQLite,
+// Postgres, and MySQL drivers since the query shape is identical across
+// all three.
+type sqlStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// rebind rewrites a query written with `?` placeholders into the
+// placeholder syntax s.driver's driver actually accepts. lib/pq (and
+// Postgres generally) only understands positional `$1, $2, ...`
+// parameters, not `?`, so every query has to go through this before
+// it's run.
+func (s *sqlStore) rebind(query string) string {
+	if s.driver != "postgres" {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// NewTokenStore opens a database connection according to cfg and returns
+// a TokenStore backed by it. The returned store owns the connection pool
+// and must be closed by the caller.
+func NewTokenStore(cfg StoreConfig) (TokenStore, error) {
+	switch cfg.Driver {
+	case "sqlite3", "postgres", "mysql":
+		db, err := sql.Open(cfg.Driver, cfg.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s store: %w", cfg.Driver, err)
+		}
+		if cfg.MaxOpenConns > 0 {
+			db.SetMaxOpenConns(cfg.MaxOpenConns)
+		}
+		if err := db.Ping(); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("connecting to %s store: %w", cfg.Driver, err)
+		}
+		return withCache(&sqlStore{db: db, driver: cfg.Driver}, cfg.CacheSize), nil
+	default:
+		return nil, fmt.Errorf("unsupported store driver %q (want sqlite3, postgres, or mysql)", cfg.Driver)
+	}
+}
+
+func (s *sqlStore) Lookup(ctx context.Context, hash string) (int, error) {
+	ctx, span := tracer.Start(ctx, "TokenStore.Lookup", trace.WithAttributes(
+		attribute.String("token.hash_prefix", hashPrefix(hash)),
+	))
+	defer span.End()
+
+	start := time.Now()
+	var value int
+	query := s.rebind("SELECT token_value FROM tokens WHERE token_hash = ?")
+	err := s.db.QueryRowContext(ctx, query, hash).Scan(&value)
+	dbLookupDuration.Observe(time.Since(start).Seconds())
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrTokenNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("looking up token hash: %w", err)
+	}
+	return value, nil
+}
+
+// sqliteMaxVariables is SQLite's default limit on the number of bound
+// parameters in a single statement (SQLITE_MAX_VARIABLE_NUMBER). Postgres
+// and MySQL tolerate far larger IN clauses, but chunking to this size is
+// harmless for them too, so BatchLookup uses one chunk size for every
+// driver rather than branching on cfg.Driver.
+const sqliteMaxVariables = 999
+
+func (s *sqlStore) BatchLookup(ctx context.Context, hashes []string) (map[string]int, error) {
+	ctx, span := tracer.Start(ctx, "TokenStore.BatchLookup", trace.WithAttributes(
+		attribute.Int("token.batch_size", len(hashes)),
+	))
+	defer span.End()
+	start := time.Now()
+	defer func() { dbLookupDuration.Observe(time.Since(start).Seconds()) }()
+
+	unique := dedupe(hashes)
+	results := make(map[string]int, len(unique))
+
+	for chunkStart := 0; chunkStart < len(unique); chunkStart += sqliteMaxVariables {
+		chunkEnd := chunkStart + sqliteMaxVariables
+		if chunkEnd > len(unique) {
+			chunkEnd = len(unique)
+		}
+		chunk := unique[chunkStart:chunkEnd]
+
+		placeholders := strings.Repeat("?,", len(chunk))
+		placeholders = placeholders[:len(placeholders)-1]
+
+		args := make([]interface{}, len(chunk))
+		for i, h := range chunk {
+			args[i] = h
+		}
+
+		query := s.rebind(fmt.Sprintf("SELECT token_hash, token_value FROM tokens WHERE token_hash IN (%s)", placeholders))
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("batch looking up token hashes: %w", err)
+		}
+		for rows.Next() {
+			var hash string
+			var value int
+			if err := rows.Scan(&hash, &value); err != nil {
+				rows.Close()
+				span.RecordError(err)
+				return nil, fmt.Errorf("scanning batch lookup row: %w", err)
+			}
+			results[hash] = value
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			span.RecordError(err)
+			return nil, fmt.Errorf("iterating batch lookup rows: %w", err)
+		}
+		rows.Close()
+	}
+
+	return results, nil
+}
+
+// dedupe returns hashes with duplicates removed, preserving first-seen
+// order.
+func dedupe(hashes []string) []string {
+	seen := make(map[string]struct{}, len(hashes))
+	unique := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		if _, ok := seen[h]; ok {
+			continue
+		}
+		seen[h] = struct{}{}
+		unique = append(unique, h)
+	}
+	return unique
+}
+
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *sqlStore) Ping() error {
+	return s.db.Ping()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// streamVerifyRecord is one line of the NDJSON response emitted by
+// verifyTokensStreamHandler.
+type streamVerifyRecord struct {
+	Hash   string `json:"hash"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// verifyTokensStreamHandler verifies token hashes one at a time as they
+// arrive on the request body (one hash per line) and flushes a result
+// for each as soon as it's resolved, so neither side has to buffer an
+// entire multi-million-token batch in memory.
+func verifyTokensStreamHandler(c echo.Context) error {
+	inFlightRequests.Inc()
+	defer inFlightRequests.Dec()
+	defer func(start time.Time) {
+		handlerDuration.WithLabelValues("verify_stream").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	res.Header().Set("X-TokenMap-Version", levels.Version())
+	res.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(res)
+	scanner := bufio.NewScanner(c.Request().Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		tokenHash := scanner.Text()
+		if tokenHash == "" {
+			continue
+		}
+
+		p := parseToken(tokenHash)
+		var values map[string]int
+		if p.valid {
+			// TokenStore is deliberately hash-only (see sqlStore in
+			// store.go), so the level being looked up is attached here
+			// rather than inside BatchLookup itself.
+			ctx, span := tracer.Start(c.Request().Context(), "verifyTokensStreamHandler.BatchLookup", trace.WithAttributes(
+				attribute.Int("token.level", p.level),
+			))
+			var err error
+			values, err = store.BatchLookup(ctx, []string{p.hash})
+			span.End()
+			if err != nil {
+				return err
+			}
+		}
+		valid, reason := verifyOne(p, values)
+		tokenVerifyTotal.WithLabelValues(resultLabel(valid, reason)).Inc()
+
+		if err := encoder.Encode(streamVerifyRecord{Hash: tokenHash, Valid: valid, Reason: reason}); err != nil {
+			return err
+		}
+		res.Flush()
+	}
+
+	return scanner.Err()
+}
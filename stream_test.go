@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerifyTokensStreamHandler(t *testing.T) {
+	newTestLevels(t)
+
+	hash := strings.Repeat("a", 64)
+	store = &memStore{values: map[string]int{hash: 50}}
+	t.Setenv(jwtSigningKeyEnv, "test-secret")
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/verify/stream", strings.NewReader("001"+hash+"\n"))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d (missing jwt)", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestVerifyTokensStreamHandler_WithAuth(t *testing.T) {
+	newTestLevels(t)
+
+	hash := strings.Repeat("a", 64)
+	store = &memStore{values: map[string]int{hash: 50}}
+
+	signingKey := "test-secret"
+	t.Setenv(jwtSigningKeyEnv, signingKey)
+
+	e, err := newServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := mustSignedJWT(t, signingKey)
+
+	req := httptest.NewRequest(http.MethodPost, "/verify/stream", strings.NewReader("001"+hash+"\n"))
+	req.Header.Set("Authorization", "Bearer "+signed)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body=%s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(rec.Body.Bytes()))
+	if !scanner.Scan() {
+		t.Fatal("expected one NDJSON line in the response")
+	}
+
+	var got streamVerifyRecord
+	if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+		t.Fatalf("decode line: %v", err)
+	}
+	if !got.Valid {
+		t.Fatalf("got %+v, want Valid=true", got)
+	}
+}
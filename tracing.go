@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelExporterEndpointEnv is the standard OTel env var for the
+// collector/Jaeger endpoint; see docker-compose.yaml for a local Jaeger
+// instance listening on it.
+const otelExporterEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// setupTracing configures the global TracerProvider to export spans via
+// OTLP/gRPC and to propagate/accept W3C traceparent headers. It returns a
+// shutdown func the caller should defer, which flushes any buffered
+// spans. If OTEL_EXPORTER_OTLP_ENDPOINT isn't set, tracing is a no-op.
+func setupTracing(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if os.Getenv(otelExporterEndpointEnv) == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("token-verifier"),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
+
+// otelEchoMiddleware extracts an incoming W3C traceparent header (if any)
+// and starts a span for the request, named after the matched route, as a
+// child of it. There's no echo-contrib package for this (it doesn't ship
+// an otelecho module), so this wraps go.opentelemetry.io/otel directly —
+// enough of what an Echo-specific integration would do for a service this
+// size.
+func otelEchoMiddleware(serviceName string) echo.MiddlewareFunc {
+	serviceTracer := otel.Tracer(serviceName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := otel.GetTextMapPropagator().Extract(c.Request().Context(), propagation.HeaderCarrier(c.Request().Header))
+
+			ctx, span := serviceTracer.Start(ctx, c.Path(), trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request().Method),
+				semconv.URLPath(c.Request().URL.Path),
+			))
+			defer span.End()
+
+			c.SetRequest(c.Request().WithContext(ctx))
+			err := next(c)
+			if err != nil {
+				span.RecordError(err)
+			}
+			return err
+		}
+	}
+}